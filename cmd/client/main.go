@@ -0,0 +1,60 @@
+// Command client — простой CLI поверх ParcelService, используется для
+// ручной проверки сервера и демонстрации его RPC.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/chnmk/go-db-practice/internal/proto"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "адрес gRPC-сервера")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("dial %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	client := pb.NewParcelServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Add(ctx, &pb.AddRequest{Parcel: &pb.Parcel{
+		Client:    1000,
+		Status:    "registered",
+		Address:   "example address",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}})
+	if err != nil {
+		log.Fatalf("add: %v", err)
+	}
+	fmt.Printf("added parcel %d\n", resp.GetNumber())
+
+	stream, err := client.GetByClient(ctx, &pb.GetByClientRequest{Client: 1000})
+	if err != nil {
+		log.Fatalf("get by client: %v", err)
+	}
+
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("recv: %v", err)
+		}
+		fmt.Printf("parcel %d: %s, %s\n", p.GetNumber(), p.GetStatus(), p.GetAddress())
+	}
+}
@@ -0,0 +1,69 @@
+// Command migrate применяет или откатывает миграции БД, выбранной через
+// переменные окружения DB_DRIVER и DB_DSN.
+//
+// Использование:
+//
+//	migrate up
+//	migrate down
+//	migrate to <version>
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"strconv"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+
+	"github.com/chnmk/go-db-practice/internal/migrate"
+)
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		log.Fatal("usage: migrate up|down|to <version>")
+	}
+
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = "sqlite"
+	}
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		dsn = "tracker.db"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		log.Fatalf("open %s: %v", driver, err)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		err = migrate.Up(db, driver)
+	case "down":
+		err = migrate.Down(db, driver)
+	case "to":
+		if len(args) != 2 {
+			log.Fatal("usage: migrate to <version>")
+		}
+		var version int
+		version, err = strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("invalid version %q: %v", args[1], err)
+		}
+		err = migrate.To(db, driver, version)
+	default:
+		log.Fatalf("unknown command %q", args[0])
+	}
+
+	if err != nil {
+		log.Fatalf("migrate %s: %v", args[0], err)
+	}
+}
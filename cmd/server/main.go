@@ -0,0 +1,40 @@
+// Command server запускает gRPC-сервер ParcelService поверх хранилища,
+// выбранного через переменные окружения DB_DRIVER и DB_DSN.
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/chnmk/go-db-practice/internal/parcelserver"
+	pb "github.com/chnmk/go-db-practice/internal/proto"
+	"github.com/chnmk/go-db-practice/internal/store"
+)
+
+func main() {
+	s, err := store.NewStoreFromEnv()
+	if err != nil {
+		log.Fatalf("open store: %v", err)
+	}
+
+	addr := os.Getenv("GRPC_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("listen %s: %v", addr, err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterParcelServiceServer(srv, parcelserver.New(s))
+
+	log.Printf("parcel gRPC server listening on %s", addr)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
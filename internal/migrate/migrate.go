@@ -0,0 +1,244 @@
+// Package migrate применяет версионированные SQL-миграции к базе данных,
+// отслеживая уже применённые версии в таблице schema_migrations.
+package migrate
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration описывает одну версию схемы: SQL для накатки и отката.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations читает встроенные файлы migrations/*.<driver>.{up,down}.sql
+// для указанного драйвера и собирает из них список миграций, отсортированный
+// по возрастанию версии. Схема иногда различается между СУБД (например,
+// способ генерации number), поэтому каждая миграция хранится в отдельном
+// файле на драйвер, а не переиспользуется между ними.
+func loadMigrations(driver string) ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*migration{}
+
+	for _, e := range entries {
+		name := e.Name()
+
+		version, migName, fileDriver, ok := parseFileName(name)
+		if !ok || fileDriver != driver {
+			continue
+		}
+
+		content, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &migration{version: version, name: migName}
+			byVersion[version] = m
+		}
+
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			m.up = string(content)
+		case strings.HasSuffix(name, ".down.sql"):
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	return migrations, nil
+}
+
+// parseFileName разбирает имя файла вида 0001_create_parcel.postgres.up.sql
+// на номер версии, имя миграции и драйвер, для которого она предназначена.
+func parseFileName(name string) (version int, migName string, driver string, ok bool) {
+	parts := strings.SplitN(name, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", "", false
+	}
+
+	v, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	rest := strings.TrimSuffix(strings.TrimSuffix(parts[1], ".up.sql"), ".down.sql")
+
+	nameParts := strings.SplitN(rest, ".", 2)
+	if len(nameParts) != 2 {
+		return 0, "", "", false
+	}
+
+	return v, nameParts[0], nameParts[1], true
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`
+
+func ensureSchemaTable(db *sql.DB) error {
+	_, err := db.Exec(createSchemaMigrations)
+	return err
+}
+
+func appliedVersions(db *sql.DB) (map[int]bool, error) {
+	rows, err := db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// placeholder возвращает синтаксис плейсхолдера номер n для указанного драйвера.
+func placeholder(driver string, n int) string {
+	if driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Up применяет все ещё не применённые миграции по возрастанию версии.
+func Up(db *sql.DB, driver string) error {
+	return To(db, driver, -1)
+}
+
+// Down откатывает последнюю применённую миграцию.
+func Down(db *sql.DB, driver string) error {
+	migrations, err := loadMigrations(driver)
+	if err != nil {
+		return err
+	}
+	if err := ensureSchemaTable(db); err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	var last *migration
+	for i := range migrations {
+		if applied[migrations[i].version] {
+			last = &migrations[i]
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	return applyDown(db, driver, *last)
+}
+
+// To приводит схему ровно к состоянию после миграции с номером version
+// включительно: недостающие миграции до version применяются, а уже
+// применённые миграции с номером выше version откатываются в порядке
+// убывания версии. version == -1 означает "применить все миграции".
+func To(db *sql.DB, driver string, version int) error {
+	migrations, err := loadMigrations(driver)
+	if err != nil {
+		return err
+	}
+	if err := ensureSchemaTable(db); err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	if version != -1 {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if m.version <= version || !applied[m.version] {
+				continue
+			}
+			if err := applyDown(db, driver, m); err != nil {
+				return fmt.Errorf("revert migration %04d_%s: %w", m.version, m.name, err)
+			}
+		}
+	}
+
+	for _, m := range migrations {
+		if version != -1 && m.version > version {
+			break
+		}
+		if applied[m.version] {
+			continue
+		}
+		if err := applyUp(db, driver, m); err != nil {
+			return fmt.Errorf("apply migration %04d_%s: %w", m.version, m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyUp(db *sql.DB, driver string, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.up); err != nil {
+		return err
+	}
+	query := fmt.Sprintf("INSERT INTO schema_migrations (version) VALUES (%s)", placeholder(driver, 1))
+	if _, err := tx.Exec(query, m.version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func applyDown(db *sql.DB, driver string, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.down); err != nil {
+		return err
+	}
+	query := fmt.Sprintf("DELETE FROM schema_migrations WHERE version = %s", placeholder(driver, 1))
+	if _, err := tx.Exec(query, m.version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestDB открывает изолированную in-memory базу SQLite для одного теста.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.NewString())
+
+	db, err := sql.Open("sqlite", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// tableExists проверяет наличие таблицы parcel, чтобы убедиться, что To
+// действительно откатывает или накатывает схему, а не только строки
+// schema_migrations.
+func tableExists(t *testing.T, db *sql.DB) bool {
+	t.Helper()
+
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'parcel'").Scan(&count)
+	require.NoError(t, err)
+
+	return count > 0
+}
+
+// TestToUpgrades проверяет, что To(..., -1) и To(..., 1) применяют миграцию 1.
+func TestToUpgrades(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	require.NoError(t, To(db, "sqlite", -1))
+
+	applied, err := appliedVersions(db)
+	require.NoError(t, err)
+	require.True(t, applied[1])
+	require.True(t, tableExists(t, db))
+}
+
+// TestToDowngrades проверяет, что To возвращает схему к более раннему
+// состоянию, откатывая уже применённые миграции выше запрошенной версии.
+func TestToDowngrades(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	require.NoError(t, To(db, "sqlite", 1))
+	require.True(t, tableExists(t, db))
+
+	require.NoError(t, To(db, "sqlite", 0))
+
+	applied, err := appliedVersions(db)
+	require.NoError(t, err)
+	require.False(t, applied[1])
+	require.False(t, tableExists(t, db))
+
+	// Повторный To до версии 1 должен снова накатить миграцию.
+	require.NoError(t, To(db, "sqlite", 1))
+	applied, err = appliedVersions(db)
+	require.NoError(t, err)
+	require.True(t, applied[1])
+	require.True(t, tableExists(t, db))
+}
+
+// TestDown проверяет, что Down откатывает последнюю применённую миграцию и
+// что повторный вызов на пустой схеме — это no-op, а не ошибка.
+func TestDown(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	require.NoError(t, Up(db, "sqlite"))
+
+	applied, err := appliedVersions(db)
+	require.NoError(t, err)
+	require.True(t, applied[1])
+
+	require.NoError(t, Down(db, "sqlite"))
+
+	applied, err = appliedVersions(db)
+	require.NoError(t, err)
+	require.False(t, applied[1])
+	require.False(t, tableExists(t, db))
+
+	require.NoError(t, Down(db, "sqlite"))
+}
@@ -0,0 +1,116 @@
+// Package parcelserver реализует gRPC-сервис ParcelService поверх store.Store.
+package parcelserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/chnmk/go-db-practice/internal/proto"
+	"github.com/chnmk/go-db-practice/internal/store"
+)
+
+// Server реализует pb.ParcelServiceServer, делегируя вызовы store.Store.
+type Server struct {
+	pb.UnimplementedParcelServiceServer
+
+	store store.Store
+}
+
+// New создаёт обработчик gRPC поверх переданного хранилища.
+func New(s store.Store) *Server {
+	return &Server{store: s}
+}
+
+func toProto(p store.Parcel) *pb.Parcel {
+	return &pb.Parcel{
+		Number:    int32(p.Number),
+		Client:    int32(p.Client),
+		Status:    p.Status,
+		Address:   p.Address,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func fromProto(p *pb.Parcel) store.Parcel {
+	return store.Parcel{
+		Number:    int(p.GetNumber()),
+		Client:    int(p.GetClient()),
+		Status:    p.GetStatus(),
+		Address:   p.GetAddress(),
+		CreatedAt: p.GetCreatedAt(),
+	}
+}
+
+func (s *Server) Add(ctx context.Context, req *pb.AddRequest) (*pb.AddResponse, error) {
+	number, err := s.store.Add(fromProto(req.GetParcel()))
+	if err != nil {
+		return nil, translateErr(err)
+	}
+
+	return &pb.AddResponse{Number: int32(number)}, nil
+}
+
+func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	p, err := s.store.Get(int(req.GetNumber()))
+	if err != nil {
+		return nil, translateErr(err)
+	}
+
+	return &pb.GetResponse{Parcel: toProto(p)}, nil
+}
+
+func (s *Server) GetByClient(req *pb.GetByClientRequest, stream pb.ParcelService_GetByClientServer) error {
+	parcels, err := s.store.GetByClient(int(req.GetClient()))
+	if err != nil {
+		return translateErr(err)
+	}
+
+	for _, p := range parcels {
+		if err := stream.Send(toProto(p)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) SetStatus(ctx context.Context, req *pb.SetStatusRequest) (*emptypb.Empty, error) {
+	if err := s.store.SetStatus(int(req.GetNumber()), req.GetStatus()); err != nil {
+		return nil, translateErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) SetAddress(ctx context.Context, req *pb.SetAddressRequest) (*emptypb.Empty, error) {
+	if err := s.store.SetAddress(int(req.GetNumber()), req.GetAddress()); err != nil {
+		return nil, translateErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := s.store.Delete(int(req.GetNumber())); err != nil {
+		return nil, translateErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// translateErr переводит доменные ошибки store в коды ошибок gRPC.
+func translateErr(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, store.ErrNotRegistered), errors.Is(err, store.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
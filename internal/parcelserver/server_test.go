@@ -0,0 +1,130 @@
+package parcelserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/chnmk/go-db-practice/internal/proto"
+	"github.com/chnmk/go-db-practice/internal/store"
+	"github.com/chnmk/go-db-practice/internal/testutil"
+)
+
+const bufSize = 1024 * 1024
+
+// startTestServer поднимает gRPC-сервер поверх изолированной in-memory
+// SQLite-базы и bufconn-слушателя, возвращая клиент ParcelService.
+func startTestServer(t *testing.T) pb.ParcelServiceClient {
+	db := testutil.NewTestDB(t)
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	pb.RegisterParcelServiceServer(srv, New(store.NewSQLiteStore(db)))
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewParcelServiceClient(conn)
+}
+
+func TestServer_AddGetDelete(t *testing.T) {
+	client := startTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addResp, err := client.Add(ctx, &pb.AddRequest{Parcel: &pb.Parcel{
+		Client:    1000,
+		Status:    "registered",
+		Address:   "test",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}})
+	require.NoError(t, err)
+	require.NotZero(t, addResp.GetNumber())
+
+	getResp, err := client.Get(ctx, &pb.GetRequest{Number: addResp.GetNumber()})
+	require.NoError(t, err)
+	require.Equal(t, "test", getResp.GetParcel().GetAddress())
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Number: addResp.GetNumber()})
+	require.NoError(t, err)
+
+	_, err = client.Get(ctx, &pb.GetRequest{Number: addResp.GetNumber()})
+	require.Error(t, err)
+}
+
+func TestServer_SetStatusAndAddress(t *testing.T) {
+	client := startTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addResp, err := client.Add(ctx, &pb.AddRequest{Parcel: &pb.Parcel{
+		Client:    1000,
+		Status:    "registered",
+		Address:   "test",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}})
+	require.NoError(t, err)
+
+	_, err = client.SetAddress(ctx, &pb.SetAddressRequest{Number: addResp.GetNumber(), Address: "new address"})
+	require.NoError(t, err)
+
+	_, err = client.SetStatus(ctx, &pb.SetStatusRequest{Number: addResp.GetNumber(), Status: "sent"})
+	require.NoError(t, err)
+
+	_, err = client.SetStatus(ctx, &pb.SetStatusRequest{Number: addResp.GetNumber(), Status: "delivered"})
+	require.NoError(t, err)
+
+	getResp, err := client.Get(ctx, &pb.GetRequest{Number: addResp.GetNumber()})
+	require.NoError(t, err)
+	require.Equal(t, "new address", getResp.GetParcel().GetAddress())
+	require.Equal(t, "delivered", getResp.GetParcel().GetStatus())
+}
+
+func TestServer_GetByClient(t *testing.T) {
+	client := startTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	customer := int32(424242)
+	for i := 0; i < 3; i++ {
+		_, err := client.Add(ctx, &pb.AddRequest{Parcel: &pb.Parcel{
+			Client:    customer,
+			Status:    "registered",
+			Address:   "test",
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		}})
+		require.NoError(t, err)
+	}
+
+	stream, err := client.GetByClient(ctx, &pb.GetByClientRequest{Client: customer})
+	require.NoError(t, err)
+
+	var got int
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got++
+	}
+	require.Equal(t, 3, got)
+}
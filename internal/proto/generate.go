@@ -0,0 +1,4 @@
+// Package proto содержит сгенерированные из parcel.proto клиент и сервер gRPC.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative parcel.proto
@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: parcel.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ParcelService_Add_FullMethodName         = "/parcel.ParcelService/Add"
+	ParcelService_Get_FullMethodName         = "/parcel.ParcelService/Get"
+	ParcelService_GetByClient_FullMethodName = "/parcel.ParcelService/GetByClient"
+	ParcelService_SetStatus_FullMethodName   = "/parcel.ParcelService/SetStatus"
+	ParcelService_SetAddress_FullMethodName  = "/parcel.ParcelService/SetAddress"
+	ParcelService_Delete_FullMethodName      = "/parcel.ParcelService/Delete"
+)
+
+// ParcelServiceClient is the client API for ParcelService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ParcelServiceClient interface {
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	GetByClient(ctx context.Context, in *GetByClientRequest, opts ...grpc.CallOption) (ParcelService_GetByClientClient, error)
+	SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetAddress(ctx context.Context, in *SetAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type parcelServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewParcelServiceClient(cc grpc.ClientConnInterface) ParcelServiceClient {
+	return &parcelServiceClient{cc}
+}
+
+func (c *parcelServiceClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
+	out := new(AddResponse)
+	err := c.cc.Invoke(ctx, ParcelService_Add_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parcelServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, ParcelService_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parcelServiceClient) GetByClient(ctx context.Context, in *GetByClientRequest, opts ...grpc.CallOption) (ParcelService_GetByClientClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ParcelService_ServiceDesc.Streams[0], ParcelService_GetByClient_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &parcelServiceGetByClientClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ParcelService_GetByClientClient interface {
+	Recv() (*Parcel, error)
+	grpc.ClientStream
+}
+
+type parcelServiceGetByClientClient struct {
+	grpc.ClientStream
+}
+
+func (x *parcelServiceGetByClientClient) Recv() (*Parcel, error) {
+	m := new(Parcel)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *parcelServiceClient) SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ParcelService_SetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parcelServiceClient) SetAddress(ctx context.Context, in *SetAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ParcelService_SetAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parcelServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ParcelService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParcelServiceServer is the server API for ParcelService service.
+// All implementations must embed UnimplementedParcelServiceServer
+// for forward compatibility
+type ParcelServiceServer interface {
+	Add(context.Context, *AddRequest) (*AddResponse, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	GetByClient(*GetByClientRequest, ParcelService_GetByClientServer) error
+	SetStatus(context.Context, *SetStatusRequest) (*emptypb.Empty, error)
+	SetAddress(context.Context, *SetAddressRequest) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedParcelServiceServer()
+}
+
+// UnimplementedParcelServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedParcelServiceServer struct {
+}
+
+func (UnimplementedParcelServiceServer) Add(context.Context, *AddRequest) (*AddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedParcelServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedParcelServiceServer) GetByClient(*GetByClientRequest, ParcelService_GetByClientServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetByClient not implemented")
+}
+func (UnimplementedParcelServiceServer) SetStatus(context.Context, *SetStatusRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStatus not implemented")
+}
+func (UnimplementedParcelServiceServer) SetAddress(context.Context, *SetAddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAddress not implemented")
+}
+func (UnimplementedParcelServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedParcelServiceServer) mustEmbedUnimplementedParcelServiceServer() {}
+
+// UnsafeParcelServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ParcelServiceServer will
+// result in compilation errors.
+type UnsafeParcelServiceServer interface {
+	mustEmbedUnimplementedParcelServiceServer()
+}
+
+func RegisterParcelServiceServer(s grpc.ServiceRegistrar, srv ParcelServiceServer) {
+	s.RegisterService(&ParcelService_ServiceDesc, srv)
+}
+
+func _ParcelService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParcelServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParcelService_Add_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParcelServiceServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParcelService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParcelServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParcelService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParcelServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParcelService_GetByClient_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetByClientRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ParcelServiceServer).GetByClient(m, &parcelServiceGetByClientServer{stream})
+}
+
+type ParcelService_GetByClientServer interface {
+	Send(*Parcel) error
+	grpc.ServerStream
+}
+
+type parcelServiceGetByClientServer struct {
+	grpc.ServerStream
+}
+
+func (x *parcelServiceGetByClientServer) Send(m *Parcel) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ParcelService_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParcelServiceServer).SetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParcelService_SetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParcelServiceServer).SetStatus(ctx, req.(*SetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParcelService_SetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParcelServiceServer).SetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParcelService_SetAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParcelServiceServer).SetAddress(ctx, req.(*SetAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParcelService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParcelServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ParcelService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParcelServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ParcelService_ServiceDesc is the grpc.ServiceDesc for ParcelService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ParcelService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "parcel.ParcelService",
+	HandlerType: (*ParcelServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _ParcelService_Add_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ParcelService_Get_Handler,
+		},
+		{
+			MethodName: "SetStatus",
+			Handler:    _ParcelService_SetStatus_Handler,
+		},
+		{
+			MethodName: "SetAddress",
+			Handler:    _ParcelService_SetAddress_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ParcelService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetByClient",
+			Handler:       _ParcelService_GetByClient_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "parcel.proto",
+}
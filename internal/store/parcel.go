@@ -0,0 +1,22 @@
+package store
+
+// Статусы посылки.
+const (
+	ParcelStatusRegistered = "registered"
+	ParcelStatusSent       = "sent"
+	ParcelStatusDelivered  = "delivered"
+)
+
+// Parcel представляет собой посылку.
+type Parcel struct {
+	// Number уникальный идентификатор посылки
+	Number int
+	// Client идентификатор клиента, который отправил посылку
+	Client int
+	// Status статус посылки
+	Status string
+	// Address адрес посылки
+	Address string
+	// CreatedAt дата и время создания посылки
+	CreatedAt string
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore реализует Store поверх PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore создаёт хранилище на основе уже открытого соединения с PostgreSQL.
+func NewPostgresStore(db *sql.DB) PostgresStore {
+	return PostgresStore{db: db}
+}
+
+func (s PostgresStore) Add(p Parcel) (int, error) {
+	// Добавление строки в таблицу parcel, идентификатор возвращается через RETURNING
+	var number int
+
+	row := s.db.QueryRow(
+		"INSERT INTO parcel (client, status, address, created_at) VALUES ($1, $2, $3, $4) RETURNING number",
+		p.Client, p.Status, p.Address, p.CreatedAt,
+	)
+	if err := row.Scan(&number); err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (s PostgresStore) Get(number int) (Parcel, error) {
+	// Чтение строки по заданному number,
+	// заполнение объекта Parcel данными из таблицы.
+	// Здесь из таблицы должна вернуться только одна строка
+	var p Parcel
+
+	row := s.db.QueryRow("SELECT number, client, status, address, created_at FROM parcel WHERE number = $1", number)
+	if err := row.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt); err != nil {
+		return Parcel{}, err
+	}
+
+	return p, nil
+}
+
+func (s PostgresStore) GetByClient(client int) ([]Parcel, error) {
+	// Чтение строк из таблицы parcel по заданному client.
+	// Здесь из таблицы может вернуться несколько строк
+	var res []Parcel
+
+	rows, err := s.db.Query("SELECT number, client, status, address, created_at FROM parcel WHERE client = $1", client)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		p := Parcel{}
+
+		if err := rows.Scan(&p.Number, &p.Client, &p.Status, &p.Address, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		// Заполнение среза Parcel данными из таблицы
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		// Проверяем курсор на наличие ошибок
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s PostgresStore) SetStatus(number int, status string) error {
+	// Обновление статуса в таблице parcel одним запросом: новый статус
+	// применяется только если текущий статус посылки разрешает такой переход
+	prevStatuses := allowedPrevStatuses(status)
+	if len(prevStatuses) == 0 {
+		return ErrInvalidTransition
+	}
+
+	placeholders := make([]string, len(prevStatuses))
+	args := []any{status, number}
+	for i, prev := range prevStatuses {
+		placeholders[i] = fmt.Sprintf("$%d", i+3)
+		args = append(args, prev)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE parcel SET status = $1 WHERE number = $2 AND status IN (%s)",
+		strings.Join(placeholders, ", "),
+	)
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, number, ErrInvalidTransition, s.Get)
+}
+
+func (s PostgresStore) SetAddress(number int, address string) error {
+	// Обновление адреса в таблице parcel.
+	// Менять адрес можно только если значение статуса registered
+	res, err := s.db.Exec(
+		"UPDATE parcel SET address = $1 WHERE number = $2 AND status = $3",
+		address, number, ParcelStatusRegistered,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, number, ErrNotRegistered, s.Get)
+}
+
+func (s PostgresStore) Delete(number int) error {
+	// Удаление строки из таблицы parcel.
+	// Удалять строку можно только если значение статуса registered
+	res, err := s.db.Exec(
+		"DELETE FROM parcel WHERE number = $1 AND status = $2",
+		number, ParcelStatusRegistered,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, number, ErrNotRegistered, s.Get)
+}
@@ -1,18 +1,24 @@
-package main
+package store
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite"
 )
 
-type ParcelStore struct {
+// SQLiteStore реализует Store поверх SQLite.
+type SQLiteStore struct {
 	db *sql.DB
 }
 
-func NewParcelStore(db *sql.DB) ParcelStore {
-	return ParcelStore{db: db}
+// NewSQLiteStore создаёт хранилище на основе уже открытого соединения с SQLite.
+func NewSQLiteStore(db *sql.DB) SQLiteStore {
+	return SQLiteStore{db: db}
 }
 
-func (s ParcelStore) Add(p Parcel) (int, error) {
+func (s SQLiteStore) Add(p Parcel) (int, error) {
 	// Добавление строки в таблицу parcel
 	res, err := s.db.Exec("INSERT INTO parcel (client, status, address, created_at) VALUES (:client, :status, :address, :created_at)",
 		sql.Named("client", p.Client),
@@ -33,7 +39,7 @@ func (s ParcelStore) Add(p Parcel) (int, error) {
 	return int(id), nil
 }
 
-func (s ParcelStore) Get(number int) (Parcel, error) {
+func (s SQLiteStore) Get(number int) (Parcel, error) {
 	// Чтение строки по заданному number,
 	// заполнение объекта Parcel данными из таблицы.
 	// Здесь из таблицы должна вернуться только одна строка
@@ -48,7 +54,7 @@ func (s ParcelStore) Get(number int) (Parcel, error) {
 	return p, nil
 }
 
-func (s ParcelStore) GetByClient(client int) ([]Parcel, error) {
+func (s SQLiteStore) GetByClient(client int) ([]Parcel, error) {
 	// Чтение строк из таблицы parcel по заданному client.
 	// Здесь из таблицы может вернуться несколько строк
 	var res []Parcel
@@ -79,23 +85,39 @@ func (s ParcelStore) GetByClient(client int) ([]Parcel, error) {
 	return res, nil
 }
 
-func (s ParcelStore) SetStatus(number int, status string) error {
-	// Обновление статуса в таблице parcel
-	_, err := s.db.Exec("UPDATE parcel SET status = :status WHERE number = :number",
-		sql.Named("status", status),
-		sql.Named("number", number),
+func (s SQLiteStore) SetStatus(number int, status string) error {
+	// Обновление статуса в таблице parcel одним запросом: новый статус
+	// применяется только если текущий статус посылки разрешает такой переход
+	prevStatuses := allowedPrevStatuses(status)
+	if len(prevStatuses) == 0 {
+		return ErrInvalidTransition
+	}
+
+	placeholders := make([]string, len(prevStatuses))
+	args := []any{sql.Named("status", status), sql.Named("number", number)}
+	for i, prev := range prevStatuses {
+		name := fmt.Sprintf("prev%d", i)
+		placeholders[i] = ":" + name
+		args = append(args, sql.Named(name, prev))
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE parcel SET status = :status WHERE number = :number AND status IN (%s)",
+		strings.Join(placeholders, ", "),
 	)
+
+	res, err := s.db.Exec(query, args...)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return checkAffected(res, number, ErrInvalidTransition, s.Get)
 }
 
-func (s ParcelStore) SetAddress(number int, address string) error {
+func (s SQLiteStore) SetAddress(number int, address string) error {
 	// Обновление адреса в таблице parcel.
 	// Менять адрес можно только если значение статуса registered
-	_, err := s.db.Exec("UPDATE parcel SET address = :address WHERE number = :number AND status = :status",
+	res, err := s.db.Exec("UPDATE parcel SET address = :address WHERE number = :number AND status = :status",
 		sql.Named("address", address),
 		sql.Named("number", number),
 		sql.Named("status", ParcelStatusRegistered),
@@ -104,13 +126,13 @@ func (s ParcelStore) SetAddress(number int, address string) error {
 		return err
 	}
 
-	return nil
+	return checkAffected(res, number, ErrNotRegistered, s.Get)
 }
 
-func (s ParcelStore) Delete(number int) error {
+func (s SQLiteStore) Delete(number int) error {
 	// Удаление строки из таблицы parcel.
 	// Удалять строку можно только если значение статуса registered
-	_, err := s.db.Exec("DELETE FROM parcel WHERE number = :number AND status = :status",
+	res, err := s.db.Exec("DELETE FROM parcel WHERE number = :number AND status = :status",
 		sql.Named("number", number),
 		sql.Named("status", ParcelStatusRegistered),
 	)
@@ -118,5 +140,5 @@ func (s ParcelStore) Delete(number int) error {
 		return err
 	}
 
-	return nil
+	return checkAffected(res, number, ErrNotRegistered, s.Get)
 }
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/chnmk/go-db-practice/internal/migrate"
+)
+
+// ErrNotRegistered возвращается, когда SetAddress или Delete вызываются
+// для посылки, статус которой уже не registered.
+var ErrNotRegistered = errors.New("store: parcel is not in registered status")
+
+// ErrInvalidTransition возвращается, когда SetStatus запрашивает переход,
+// не разрешённый statusTransitions для текущего статуса посылки.
+var ErrInvalidTransition = errors.New("store: invalid parcel status transition")
+
+// statusTransitions описывает легальные переходы статуса посылки: ключ —
+// текущий статус, значение — статусы, в которые из него можно перейти.
+// Переход registered → deleted моделируется отдельно через Delete.
+var statusTransitions = map[string][]string{
+	ParcelStatusRegistered: {ParcelStatusSent},
+	ParcelStatusSent:       {ParcelStatusDelivered},
+}
+
+// StatusTransitions возвращает статусы, в которые можно перейти из status,
+// чтобы HTTP/gRPC-слой мог показывать клиентам допустимые переходы, не
+// дублируя эту таблицу.
+func StatusTransitions(status string) []string {
+	return statusTransitions[status]
+}
+
+// allowedPrevStatuses возвращает статусы, из которых можно перейти в target
+// согласно statusTransitions.
+func allowedPrevStatuses(target string) []string {
+	var prev []string
+
+	for from, to := range statusTransitions {
+		for _, t := range to {
+			if t == target {
+				prev = append(prev, from)
+			}
+		}
+	}
+
+	return prev
+}
+
+// checkAffected проверяет, что запрос действительно затронул строку. Если
+// строк не затронуто, но посылка с таким number существует, значит условие
+// запроса (статус или разрешённый переход) не выполнено, и возвращается
+// errIfUnmet. get используется, чтобы различить этот случай от отсутствия
+// посылки вовсе, и одинаков для обоих драйверов, поэтому вынесен сюда вместо
+// дублирования в SQLiteStore и PostgresStore.
+func checkAffected(res sql.Result, number int, errIfUnmet error, get func(int) (Parcel, error)) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	if _, err := get(number); err != nil {
+		return err
+	}
+
+	return errIfUnmet
+}
+
+// Store описывает операции над посылками независимо от конкретной СУБД.
+type Store interface {
+	Add(p Parcel) (int, error)
+	Get(number int) (Parcel, error)
+	GetByClient(client int) ([]Parcel, error)
+	SetStatus(number int, status string) error
+	SetAddress(number int, address string) error
+	Delete(number int) error
+}
+
+// Open открывает соединение с БД по заданным драйверу и строке подключения
+// и применяет к ней все не применённые миграции.
+func Open(driver, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate.Up(db, driver); err != nil {
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return db, nil
+}
+
+// NewStore открывает и мигрирует БД по заданным драйверу и строке подключения
+// и возвращает реализацию Store, соответствующую драйверу.
+func NewStore(driver, dsn string) (Store, error) {
+	db, err := Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch driver {
+	case "sqlite":
+		return NewSQLiteStore(db), nil
+	case "postgres":
+		return NewPostgresStore(db), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", driver)
+	}
+}
+
+// NewStoreFromEnv собирает Store по переменным окружения DB_DRIVER и DB_DSN,
+// подставляя значения по умолчанию для локального запуска без настройки.
+func NewStoreFromEnv() (Store, error) {
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = "sqlite"
+	}
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		dsn = "tracker.db"
+	}
+
+	return NewStore(driver, dsn)
+}
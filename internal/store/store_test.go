@@ -0,0 +1,336 @@
+package store
+
+import (
+	"database/sql"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chnmk/go-db-practice/internal/testutil"
+)
+
+var (
+	// randSource источник псевдо случайных чисел.
+	// Для повышения уникальности в качестве seed
+	// используется текущее время в unix формате (в виде числа)
+	randSource = rand.NewSource(time.Now().UnixNano())
+	// randRange использует randSource для генерации случайных чисел
+	randRange = rand.New(randSource)
+)
+
+// storeBackend описывает одну реализацию Store, против которой прогоняется набор тестов.
+type storeBackend struct {
+	name string
+	open func(t *testing.T) (Store, bool)
+}
+
+// storeBackends возвращает бэкенды, которые нужно проверить в этом запуске.
+// Каждый вызов open создаёт отдельную изолированную базу данных через
+// testutil. SQLite доступен всегда, PostgreSQL — только если задан
+// POSTGRES_TEST_DSN; в этом случае open возвращает ok == false, и тест
+// пропускается.
+func storeBackends() []storeBackend {
+	return []storeBackend{
+		{
+			name: "sqlite",
+			open: func(t *testing.T) (Store, bool) {
+				return NewSQLiteStore(testutil.NewTestDB(t)), true
+			},
+		},
+		{
+			name: "postgres",
+			open: func(t *testing.T) (Store, bool) {
+				db, ok := testutil.NewTestPostgresDB(t)
+				if !ok {
+					return nil, false
+				}
+				return NewPostgresStore(db), true
+			},
+		},
+	}
+}
+
+// getTestParcel возвращает тестовую посылку
+func getTestParcel() Parcel {
+	return Parcel{
+		Client:    1000,
+		Status:    ParcelStatusRegistered,
+		Address:   "test",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// TestAddGetDelete проверяет добавление, получение и удаление посылки
+func TestAddGetDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range storeBackends() {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Настройка подключения к изолированной БД
+			store, ok := backend.open(t)
+			if !ok {
+				t.Skip("POSTGRES_TEST_DSN is not set")
+			}
+
+			parcel := getTestParcel()
+
+			// add
+			// Добавляем новую посылку в БД
+			id, err := store.Add(parcel)
+
+			// Проверка на отсутствие ошибки и наличие идентификатора
+			require.NoError(t, err)
+			require.NotEmpty(t, id)
+
+			// get
+			// Получаем только что добавленную посылку, убеждаемся в отсутствии ошибки
+			p, err := store.Get(id)
+			require.NoError(t, err)
+
+			// Проверяем, что значения всех полей в полученном объекте совпадают со значениями полей в переменной parcel
+			assert.Equal(t, p.Client, parcel.Client)
+			assert.Equal(t, p.Status, parcel.Status)
+			assert.Equal(t, p.Address, parcel.Address)
+			assert.Equal(t, p.CreatedAt, parcel.CreatedAt)
+
+			// delete
+			// Удаляем добавленную посылку, убеждаемся в отсутствии ошибки
+			err = store.Delete(id)
+			require.NoError(t, err)
+
+			// Проверяем, что посылку больше нельзя получить из БД
+			_, err = store.Get(id)
+			require.Equal(t, sql.ErrNoRows, err)
+		})
+	}
+}
+
+// TestSetAddress проверяет обновление адреса
+func TestSetAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range storeBackends() {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Настройка подключения к изолированной БД
+			store, ok := backend.open(t)
+			if !ok {
+				t.Skip("POSTGRES_TEST_DSN is not set")
+			}
+
+			parcel := getTestParcel()
+
+			// add
+			// Добавляем новую посылку в БД
+			id, err := store.Add(parcel)
+
+			// Проверка на отсутствие ошибки и наличие идентификатора
+			require.NoError(t, err)
+			require.NotEmpty(t, id)
+
+			// set address
+			// Обновляем адрес
+			newAddress := "new test address"
+			err = store.SetAddress(id, newAddress)
+
+			// Проверка на отсутствие ошибки
+			require.NoError(t, err)
+
+			// check
+			// Получаем добавленную посылку
+			p, err := store.Get(id)
+
+			// Смотрим, обновился ли адрес
+			require.NoError(t, err)
+			assert.Equal(t, p.Address, newAddress)
+		})
+	}
+}
+
+// TestSetStatus проверяет обновление статуса
+func TestSetStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range storeBackends() {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Настройка подключения к изолированной БД
+			store, ok := backend.open(t)
+			if !ok {
+				t.Skip("POSTGRES_TEST_DSN is not set")
+			}
+
+			parcel := getTestParcel()
+
+			// add
+			// Добавляем новую посылку в БД
+			id, err := store.Add(parcel)
+
+			// Проверка на отсутствие ошибки и наличие идентификатора
+			require.NoError(t, err)
+			require.NotEmpty(t, id)
+
+			// set status
+			// Обновляем статус на легальный следующий по statusTransitions
+			err = store.SetStatus(id, ParcelStatusSent)
+
+			// Проверка на отсутствие ошибки
+			require.NoError(t, err)
+
+			// check
+			// Получаем добавленную посылку
+			p, err := store.Get(id)
+
+			// Смотрим, обновился ли статус
+			require.NoError(t, err)
+			assert.Equal(t, p.Status, ParcelStatusSent)
+		})
+	}
+}
+
+// TestSetStatusTransitions проверяет, что SetStatus разрешает только
+// переходы из statusTransitions и возвращает ErrInvalidTransition для
+// остальных.
+func TestSetStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{name: "registered to sent is legal", from: ParcelStatusRegistered, to: ParcelStatusSent},
+		{name: "sent to delivered is legal", from: ParcelStatusSent, to: ParcelStatusDelivered},
+		{name: "registered to delivered skips sent", from: ParcelStatusRegistered, to: ParcelStatusDelivered, wantErr: ErrInvalidTransition},
+		{name: "sent to registered goes backwards", from: ParcelStatusSent, to: ParcelStatusRegistered, wantErr: ErrInvalidTransition},
+		{name: "delivered to sent goes backwards", from: ParcelStatusDelivered, to: ParcelStatusSent, wantErr: ErrInvalidTransition},
+		{name: "delivered to registered goes backwards", from: ParcelStatusDelivered, to: ParcelStatusRegistered, wantErr: ErrInvalidTransition},
+		{name: "registered to registered is a no-op transition", from: ParcelStatusRegistered, to: ParcelStatusRegistered, wantErr: ErrInvalidTransition},
+	}
+
+	for _, backend := range storeBackends() {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, c := range cases {
+				c := c
+				t.Run(c.name, func(t *testing.T) {
+					t.Parallel()
+
+					store, ok := backend.open(t)
+					if !ok {
+						t.Skip("POSTGRES_TEST_DSN is not set")
+					}
+
+					parcel := getTestParcel()
+					parcel.Status = c.from
+
+					id, err := store.Add(parcel)
+					require.NoError(t, err)
+
+					err = store.SetStatus(id, c.to)
+					if c.wantErr != nil {
+						require.ErrorIs(t, err, c.wantErr)
+
+						p, getErr := store.Get(id)
+						require.NoError(t, getErr)
+						assert.Equal(t, c.from, p.Status)
+
+						return
+					}
+
+					require.NoError(t, err)
+
+					p, getErr := store.Get(id)
+					require.NoError(t, getErr)
+					assert.Equal(t, c.to, p.Status)
+				})
+			}
+		})
+	}
+}
+
+// TestGetByClient проверяет получение посылок по идентификатору клиента
+func TestGetByClient(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range storeBackends() {
+		backend := backend
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Настройка подключения к изолированной БД
+			store, ok := backend.open(t)
+			if !ok {
+				t.Skip("POSTGRES_TEST_DSN is not set")
+			}
+
+			parcels := []Parcel{
+				getTestParcel(),
+				getTestParcel(),
+				getTestParcel(),
+			}
+			parcelMap := map[int]Parcel{}
+
+			// Задаём всем посылкам один и тот же идентификатор клиента
+			client := randRange.Intn(10_000_000)
+			parcels[0].Client = client
+			parcels[1].Client = client
+			parcels[2].Client = client
+
+			// add
+			for i := 0; i < len(parcels); i++ {
+				// Добавляем новую посылку в БД
+				id, err := store.Add(parcels[i])
+
+				// Проверка на отсутствие ошибки и наличие идентификатора
+				require.NoError(t, err)
+				require.NotEmpty(t, id)
+
+				// Обновляем идентификатор добавленной у посылки
+				parcels[i].Number = id
+
+				// Сохраняем добавленную посылку в структуру map, чтобы её можно было легко достать по идентификатору посылки
+				parcelMap[id] = parcels[i]
+			}
+
+			// get by client
+			// Получаем список посылок по идентификатору клиента, сохранённого в переменной client
+			storedParcels, err := store.GetByClient(client)
+
+			// Проверяем, отсутствует ли ошибка
+			require.NoError(t, err)
+			// Смотрим, что количество полученных посылок совпадает с количеством добавленных
+			assert.Equal(t, len(storedParcels), len(parcels))
+
+			// check
+			for _, parcel := range storedParcels {
+				// в parcelMap лежат добавленные посылки, ключ - идентификатор посылки, значение - сама посылка
+				// Текущий идентификатор
+				n := parcel.Number
+
+				// Смотрим, что все посылки из storedParcels есть в parcelMap
+				assert.NotEmpty(t, parcelMap[n])
+
+				// Проверяем, что значения полей полученных посылок заполнены верно
+				assert.Equal(t, parcel.Address, parcelMap[n].Address)
+				assert.Equal(t, parcel.Client, parcelMap[n].Client)
+				assert.Equal(t, parcel.CreatedAt, parcelMap[n].CreatedAt)
+				assert.Equal(t, parcel.Number, parcelMap[n].Number)
+				assert.Equal(t, parcel.Status, parcelMap[n].Status)
+			}
+		})
+	}
+}